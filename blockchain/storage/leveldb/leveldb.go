@@ -15,14 +15,15 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (storage.Storage, error
 		return nil, err
 	}
 
-	kv := &levelDBKV{db}
+	kv := &levelDBKV{db: db, logger: logger.Named("blockchain-database")}
 
 	return storage.NewKeyValueStorage(logger.Named("leveldb"), kv), nil
 }
 
-// levelDBKV is the leveldb implementation of the kv storage
+// levelDBKV is the leveldb implementation of the database.KV interface
 type levelDBKV struct {
-	db *leveldb.DB
+	db     *leveldb.DB
+	logger hclog.Logger
 }
 
 // Set sets the key-value pair in leveldb storage
@@ -44,7 +45,43 @@ func (l *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// NewBatch returns a batch for grouping writes to this store
+func (l *levelDBKV) NewBatch() database.Batch {
+	return &levelDBBatch{db: l.db, batch: new(leveldb.Batch)}
+}
+
+// NewIterator returns an iterator over this store's key space
+func (l *levelDBKV) NewIterator() database.Iterator {
+	return l.db.NewIterator(nil, nil)
+}
+
+// Metrics returns a backend-agnostic snapshot of the store's disk/compaction counters
+func (l *levelDBKV) Metrics() (database.Metrics, error) {
+	return database.LevelDBMetrics(l.db, l.logger)
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDBKV) Close() error {
 	return l.db.Close()
 }
+
+// levelDBBatch is the leveldb implementation of the database.Batch interface
+type levelDBBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+// Put stages a key-value pair for writing
+func (b *levelDBBatch) Put(key, value []byte) {
+	b.batch.Put(key, value)
+}
+
+// Delete stages a key for removal
+func (b *levelDBBatch) Delete(key []byte) {
+	b.batch.Delete(key)
+}
+
+// Write commits every staged operation atomically
+func (b *levelDBBatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
@@ -0,0 +1,151 @@
+package pebble
+
+import (
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/database"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+)
+
+// NewPebbleStorage creates the new storage reference with pebble
+func NewPebbleStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	db, err := database.NewPebbleDB(path, "blockchain", logger.Named("blockchain-database"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	kv := &pebbleKV{db: db, logger: logger.Named("blockchain-database")}
+
+	return storage.NewKeyValueStorage(logger.Named("pebble"), kv), nil
+}
+
+// pebbleKV is the pebble implementation of the database.KV interface
+type pebbleKV struct {
+	db     *pebble.DB
+	logger hclog.Logger
+}
+
+// Set sets the key-value pair in pebble storage
+func (p *pebbleKV) Set(k []byte, v []byte) error {
+	return p.db.Set(k, v, nil)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (p *pebbleKV) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	value := append([]byte{}, data...)
+
+	if err := closer.Close(); err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// NewBatch returns a batch for grouping writes to this store
+func (p *pebbleKV) NewBatch() database.Batch {
+	return &pebbleBatch{batch: p.db.NewBatch()}
+}
+
+// NewIterator returns an iterator over this store's key space
+func (p *pebbleKV) NewIterator() database.Iterator {
+	it, err := p.db.NewIter(nil)
+	if err != nil {
+		p.logger.Error("failed to create pebble iterator", "err", err.Error())
+
+		return &pebbleIterator{err: err}
+	}
+
+	return &pebbleIterator{it: it}
+}
+
+// Metrics returns a backend-agnostic snapshot of the store's disk/compaction counters
+func (p *pebbleKV) Metrics() (database.Metrics, error) {
+	return database.PebbleMetrics(p.db), nil
+}
+
+// Close closes the pebble storage instance
+func (p *pebbleKV) Close() error {
+	return p.db.Close()
+}
+
+// pebbleBatch is the pebble implementation of the database.Batch interface
+type pebbleBatch struct {
+	batch *pebble.Batch
+}
+
+// Put stages a key-value pair for writing
+func (b *pebbleBatch) Put(key, value []byte) {
+	_ = b.batch.Set(key, value, nil)
+}
+
+// Delete stages a key for removal
+func (b *pebbleBatch) Delete(key []byte) {
+	_ = b.batch.Delete(key, nil)
+}
+
+// Write commits every staged operation atomically
+func (b *pebbleBatch) Write() error {
+	return b.batch.Commit(nil)
+}
+
+// pebbleIterator is the pebble implementation of the database.Iterator interface
+type pebbleIterator struct {
+	it      *pebble.Iterator
+	started bool
+
+	// err holds the error from p.db.NewIter, if iterator creation itself
+	// failed, so it isn't silently indistinguishable from an empty range.
+	err error
+}
+
+// Next advances the iterator and reports whether a pair is available
+func (i *pebbleIterator) Next() bool {
+	if i.it == nil {
+		return false
+	}
+
+	if !i.started {
+		i.started = true
+
+		return i.it.First()
+	}
+
+	return i.it.Next()
+}
+
+// Key returns the key at the current iterator position
+func (i *pebbleIterator) Key() []byte {
+	return i.it.Key()
+}
+
+// Value returns the value at the current iterator position
+func (i *pebbleIterator) Value() []byte {
+	return i.it.Value()
+}
+
+// Release releases the iterator's resources
+func (i *pebbleIterator) Release() {
+	if i.it != nil {
+		_ = i.it.Close()
+	}
+}
+
+// Error returns any accumulated error encountered during iteration, or the
+// error from iterator creation if it failed.
+func (i *pebbleIterator) Error() error {
+	if i.it == nil {
+		return i.err
+	}
+
+	return i.it.Error()
+}
@@ -0,0 +1,162 @@
+package pebble
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/hashicorp/go-hclog"
+)
+
+func newTestKV(t *testing.T) *pebbleKV {
+	t.Helper()
+
+	db, err := pebble.Open("test", &pebble.Options{FS: vfs.NewMem()})
+	if err != nil {
+		t.Fatalf("failed to open in-memory pebble db: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("failed to close pebble db: %v", err)
+		}
+	})
+
+	return &pebbleKV{db: db, logger: hclog.NewNullLogger()}
+}
+
+func TestPebbleKVSetGet(t *testing.T) {
+	kv := newTestKV(t)
+
+	if err := kv.Set([]byte("k"), []byte("v")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	value, ok, err := kv.Get([]byte("k"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !ok {
+		t.Fatalf("expected key to be found")
+	}
+
+	if string(value) != "v" {
+		t.Errorf("value = %q, want %q", value, "v")
+	}
+}
+
+func TestPebbleKVGetMissing(t *testing.T) {
+	kv := newTestKV(t)
+
+	_, ok, err := kv.Get([]byte("missing"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ok {
+		t.Fatalf("expected key to be missing")
+	}
+}
+
+func TestPebbleBatch(t *testing.T) {
+	kv := newTestKV(t)
+
+	batch := kv.NewBatch()
+	batch.Put([]byte("a"), []byte("1"))
+	batch.Put([]byte("b"), []byte("2"))
+
+	if err := batch.Write(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for key, want := range map[string]string{"a": "1", "b": "2"} {
+		value, ok, err := kv.Get([]byte(key))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !ok {
+			t.Fatalf("key %q not found after batch write", key)
+		}
+
+		if string(value) != want {
+			t.Errorf("key %q = %q, want %q", key, value, want)
+		}
+	}
+}
+
+func TestPebbleBatchDelete(t *testing.T) {
+	kv := newTestKV(t)
+
+	if err := kv.Set([]byte("k"), []byte("v")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch := kv.NewBatch()
+	batch.Delete([]byte("k"))
+
+	if err := batch.Write(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, ok, err := kv.Get([]byte("k"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ok {
+		t.Fatalf("expected key to be deleted")
+	}
+}
+
+func TestPebbleIterator(t *testing.T) {
+	kv := newTestKV(t)
+
+	want := map[string]string{"a": "1", "b": "2", "c": "3"}
+	for key, value := range want {
+		if err := kv.Set([]byte(key), []byte(value)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	it := kv.NewIterator()
+	defer it.Release()
+
+	got := make(map[string]string, len(want))
+	for it.Next() {
+		got[string(it.Key())] = string(it.Value())
+	}
+
+	if err := it.Error(); err != nil {
+		t.Fatalf("unexpected iterator error: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("key %q = %q, want %q", key, got[key], value)
+		}
+	}
+}
+
+func TestPebbleIteratorCreationErrorSurfacesFromError(t *testing.T) {
+	kv := newTestKV(t)
+
+	if err := kv.db.Close(); err != nil {
+		t.Fatalf("failed to close pebble db: %v", err)
+	}
+
+	it := kv.NewIterator()
+
+	if it.Next() {
+		t.Fatalf("expected Next to report no items on a failed iterator")
+	}
+
+	if err := it.Error(); err == nil {
+		t.Fatalf("expected Error() to surface the iterator creation failure")
+	}
+}
@@ -0,0 +1,14 @@
+package database
+
+// WriteBatch stages every pair in a single Batch and commits it in one call,
+// so a caller writing many keys at once (e.g. a trie/state commit) pays one
+// syscall for the whole set instead of one per key.
+func WriteBatch(kv KV, pairs map[string][]byte) error {
+	batch := kv.NewBatch()
+
+	for key, value := range pairs {
+		batch.Put([]byte(key), value)
+	}
+
+	return batch.Write()
+}
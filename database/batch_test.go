@@ -0,0 +1,103 @@
+package database
+
+import "testing"
+
+// memKV is a minimal in-memory KV used to exercise WriteBatch without
+// depending on a real goleveldb/pebble engine.
+type memKV struct {
+	data map[string][]byte
+}
+
+func newMemKV() *memKV {
+	return &memKV{data: make(map[string][]byte)}
+}
+
+func (m *memKV) Get(key []byte) ([]byte, bool, error) {
+	v, ok := m.data[string(key)]
+
+	return v, ok, nil
+}
+
+func (m *memKV) Set(key, value []byte) error {
+	m.data[string(key)] = value
+
+	return nil
+}
+
+func (m *memKV) NewBatch() Batch {
+	return &memBatch{kv: m, puts: make(map[string][]byte), deletes: make(map[string]struct{})}
+}
+
+func (m *memKV) NewIterator() Iterator     { return nil }
+func (m *memKV) Close() error              { return nil }
+func (m *memKV) Metrics() (Metrics, error) { return Metrics{}, nil }
+
+// memBatch is the memKV implementation of Batch.
+type memBatch struct {
+	kv      *memKV
+	puts    map[string][]byte
+	deletes map[string]struct{}
+}
+
+func (b *memBatch) Put(key, value []byte) {
+	delete(b.deletes, string(key))
+	b.puts[string(key)] = value
+}
+
+func (b *memBatch) Delete(key []byte) {
+	delete(b.puts, string(key))
+	b.deletes[string(key)] = struct{}{}
+}
+
+func (b *memBatch) Write() error {
+	for key := range b.deletes {
+		delete(b.kv.data, key)
+	}
+
+	for key, value := range b.puts {
+		b.kv.data[key] = value
+	}
+
+	return nil
+}
+
+func TestWriteBatch(t *testing.T) {
+	kv := newMemKV()
+
+	pairs := map[string][]byte{
+		"a": []byte("1"),
+		"b": []byte("2"),
+		"c": []byte("3"),
+	}
+
+	if err := WriteBatch(kv, pairs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for key, want := range pairs {
+		got, ok, err := kv.Get([]byte(key))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !ok {
+			t.Fatalf("key %q not found after WriteBatch", key)
+		}
+
+		if string(got) != string(want) {
+			t.Errorf("key %q = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestWriteBatchEmpty(t *testing.T) {
+	kv := newMemKV()
+
+	if err := WriteBatch(kv, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(kv.data) != 0 {
+		t.Fatalf("expected no keys written, got %d", len(kv.data))
+	}
+}
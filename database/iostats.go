@@ -0,0 +1,200 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// LevelDBMetrics reads the "leveldb.iostats" and "leveldb.stats" DB
+// properties plus the driver's native Stats() call and assembles them into
+// a backend-agnostic Metrics snapshot. Property parse failures are logged
+// and swallowed rather than propagated, since a change in the goleveldb
+// property text format shouldn't take down the metering goroutine.
+func LevelDBMetrics(db *leveldb.DB, logger hclog.Logger) (Metrics, error) {
+	var stats leveldb.DBStats
+	if err := db.Stats(&stats); err != nil {
+		return Metrics{}, err
+	}
+
+	m := Metrics{
+		DiskSize:             stats.LevelSizes.Sum(),
+		WriteDelayCount:      int64(stats.WriteDelayCount),
+		WriteDelayDuration:   stats.WriteDelayDuration.Nanoseconds(),
+		WritePaused:          stats.WritePaused,
+		DiskRead:             int64(stats.IORead),
+		DiskWrite:            int64(stats.IOWrite),
+		MemCompactions:       int64(stats.MemComp),
+		Level0Compactions:    int64(stats.Level0Comp),
+		NonLevel0Compactions: int64(stats.NonLevel0Comp),
+		SeekCompactions:      int64(stats.SeekComp),
+	}
+
+	m.LevelTableCounts = make([]int64, len(stats.LevelTablesCounts))
+	for lvl, tables := range stats.LevelTablesCounts {
+		m.LevelTableCounts[lvl] = int64(tables)
+	}
+
+	if raw, err := db.GetProperty("leveldb.iostats"); err != nil {
+		logger.Warn("failed to read leveldb.iostats property", "err", err.Error())
+	} else if readBytes, writeBytes, err := parseIOStats(raw); err != nil {
+		logger.Warn("failed to parse leveldb.iostats property", "err", err.Error())
+	} else {
+		m.IORead, m.IOWrite = readBytes, writeBytes
+	}
+
+	if raw, err := db.GetProperty("leveldb.stats"); err != nil {
+		logger.Warn("failed to read leveldb.stats property", "err", err.Error())
+	} else if sizes, durations, reads, writes, err := parseLevelStats(raw); err != nil {
+		logger.Warn("failed to parse leveldb.stats property", "err", err.Error())
+	} else {
+		m.LevelSizes, m.LevelTimes, m.LevelReads, m.LevelWrites = sizes, durations, reads, writes
+	}
+
+	return m, nil
+}
+
+// IOStats is a point-in-time snapshot of the deltas read out of leveldb's
+// "leveldb.iostats" and "leveldb.stats" properties on a single meter tick.
+// LevelSizes, LevelTimes, LevelReads and LevelWrites are indexed by level
+// number, mirroring the per-level table rows in the "leveldb.stats" text;
+// LevelSizes holds each level's current size rather than a delta.
+type IOStats struct {
+	Name string
+
+	IORead  int64
+	IOWrite int64
+
+	LevelSizes  []int64
+	LevelTimes  []int64
+	LevelReads  []int64
+	LevelWrites []int64
+}
+
+var (
+	ioStatsSubscribersLock sync.Mutex
+	ioStatsSubscribers     = make(map[chan<- IOStats]struct{})
+)
+
+// SubscribeIOStats registers ch to receive an IOStats struct on every meter
+// tick, for backends that don't want to be limited to the armon/go-metrics
+// sink (e.g. the JSON-RPC layer streaming to an operator dashboard).
+// Subscribers that can't keep up with the tick rate are skipped rather than
+// blocking the meter goroutine.
+func SubscribeIOStats(ch chan<- IOStats) {
+	ioStatsSubscribersLock.Lock()
+	defer ioStatsSubscribersLock.Unlock()
+
+	ioStatsSubscribers[ch] = struct{}{}
+}
+
+// UnsubscribeIOStats removes a channel previously registered with
+// SubscribeIOStats.
+func UnsubscribeIOStats(ch chan<- IOStats) {
+	ioStatsSubscribersLock.Lock()
+	defer ioStatsSubscribersLock.Unlock()
+
+	delete(ioStatsSubscribers, ch)
+}
+
+// publishIOStats pushes stats to every subscriber, dropping the update for
+// any subscriber whose channel is full instead of blocking the meter.
+func publishIOStats(stats IOStats) {
+	ioStatsSubscribersLock.Lock()
+	defer ioStatsSubscribersLock.Unlock()
+
+	for ch := range ioStatsSubscribers {
+		select {
+		case ch <- stats:
+		default:
+		}
+	}
+}
+
+// ioStatsRegexp matches the "Read(MB):<x> Write(MB):<y>" line returned by
+// the "leveldb.iostats" DB property.
+var ioStatsRegexp = regexp.MustCompile(`Read\(MB\):([0-9.]+)\s+Write\(MB\):([0-9.]+)`)
+
+// parseIOStats parses the "leveldb.iostats" property text into cumulative
+// read/write byte counters. It returns an error rather than panicking so a
+// change in the goleveldb property format can't kill the meter goroutine.
+func parseIOStats(raw string) (readBytes, writeBytes int64, err error) {
+	matches := ioStatsRegexp.FindStringSubmatch(raw)
+	if matches == nil {
+		return 0, 0, fmt.Errorf("unexpected leveldb.iostats format: %q", raw)
+	}
+
+	readMB, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse leveldb.iostats read value: %w", err)
+	}
+
+	writeMB, err := strconv.ParseFloat(matches[2], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse leveldb.iostats write value: %w", err)
+	}
+
+	return int64(readMB * 1024 * 1024), int64(writeMB * 1024 * 1024), nil
+}
+
+// levelStatsRegexp matches a single per-level row of the "leveldb.stats"
+// table, e.g. "   1   |         12 |        3.40000 |        0.01000 |        1.20000 |        2.30000".
+var levelStatsRegexp = regexp.MustCompile(`^\s*(\d+)\s*\|\s*\d+\s*\|\s*([0-9.]+)\s*\|\s*([0-9.]+)\s*\|\s*([0-9.]+)\s*\|\s*([0-9.]+)\s*$`)
+
+// parseLevelStats parses the per-level table embedded in the "leveldb.stats"
+// property text into size/time/read/write slices indexed by level. Lines
+// that don't match the expected table row shape are skipped rather than
+// treated as a fatal error, since the header and separator rows share the
+// same text blob.
+func parseLevelStats(raw string) (sizes, durations, reads, writes []int64, err error) {
+	for _, line := range strings.Split(raw, "\n") {
+		matches := levelStatsRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		level, convErr := strconv.Atoi(matches[1])
+		if convErr != nil {
+			continue
+		}
+
+		sizeMB, convErr := strconv.ParseFloat(matches[2], 64)
+		if convErr != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to parse leveldb.stats size for level %d: %w", level, convErr)
+		}
+
+		timeSec, convErr := strconv.ParseFloat(matches[3], 64)
+		if convErr != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to parse leveldb.stats time for level %d: %w", level, convErr)
+		}
+
+		readMB, convErr := strconv.ParseFloat(matches[4], 64)
+		if convErr != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to parse leveldb.stats read for level %d: %w", level, convErr)
+		}
+
+		writeMB, convErr := strconv.ParseFloat(matches[5], 64)
+		if convErr != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to parse leveldb.stats write for level %d: %w", level, convErr)
+		}
+
+		for len(sizes) <= level {
+			sizes = append(sizes, 0)
+			durations = append(durations, 0)
+			reads = append(reads, 0)
+			writes = append(writes, 0)
+		}
+
+		sizes[level] = int64(sizeMB * 1024 * 1024)
+		durations[level] = int64(timeSec * 1e9)
+		reads[level] = int64(readMB * 1024 * 1024)
+		writes[level] = int64(writeMB * 1024 * 1024)
+	}
+
+	return sizes, durations, reads, writes, nil
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"testing"
+)
+
+// mbToBytes converts a megabyte figure to bytes the same way the parsers do,
+// truncating at run time (a non-integer constant can't be converted to int64).
+func mbToBytes(mb float64) int64 {
+	return int64(mb * 1024 * 1024)
+}
+
+func TestParseIOStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		wantRead  int64
+		wantWrite int64
+		wantErr   bool
+	}{
+		{
+			name:      "real goleveldb format",
+			raw:       "             Read(MB):2375.46523      Write(MB):3409.59283",
+			wantRead:  mbToBytes(2375.46523),
+			wantWrite: mbToBytes(3409.59283),
+		},
+		{
+			name:      "zero values",
+			raw:       "Read(MB):0.00000      Write(MB):0.00000",
+			wantRead:  0,
+			wantWrite: 0,
+		},
+		{
+			name:    "truncated line missing write",
+			raw:     "Read(MB):123.45",
+			wantErr: true,
+		},
+		{
+			name:    "malformed number",
+			raw:     "Read(MB):abc      Write(MB):1.00000",
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			raw:     "",
+			wantErr: true,
+		},
+		{
+			name:    "unrelated property text",
+			raw:     "leveldb.num-files-at-level0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readBytes, writeBytes, err := parseIOStats(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if readBytes != tt.wantRead {
+				t.Errorf("read bytes = %d, want %d", readBytes, tt.wantRead)
+			}
+
+			if writeBytes != tt.wantWrite {
+				t.Errorf("write bytes = %d, want %d", writeBytes, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestParseLevelStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		wantSizes  []int64
+		wantWrites []int64
+		wantLevels int
+		wantErr    bool
+	}{
+		{
+			name: "real goleveldb stats table with header and separator rows",
+			raw: "" +
+				"Compactions\n" +
+				" Level |   Tables   |    Size(MB)   |    Time(sec)  |    Read(MB)   |   Write(MB)\n" +
+				"-------+------------+----------------+----------------+----------------+---------------\n" +
+				"   0   |          1 |         0.40000 |        0.00000 |        0.00000 |        0.40000\n" +
+				"   1   |         12 |         3.40000 |        0.01000 |        1.20000 |        2.30000\n",
+			wantSizes:  []int64{mbToBytes(0.4), mbToBytes(3.4)},
+			wantWrites: []int64{mbToBytes(0.4), mbToBytes(2.3)},
+			wantLevels: 2,
+		},
+		{
+			name:       "non-contiguous levels are zero-filled",
+			raw:        "   2   |          5 |         1.00000 |        0.00000 |        0.00000 |        0.10000\n",
+			wantSizes:  []int64{0, 0, mbToBytes(1.0)},
+			wantWrites: []int64{0, 0, mbToBytes(0.1)},
+			wantLevels: 3,
+		},
+		{
+			name:       "only header and separator rows, no data",
+			raw:        " Level |   Tables   |    Size(MB)   |    Time(sec)  |    Read(MB)   |   Write(MB)\n-------+------------+----------------+----------------+----------------+---------------\n",
+			wantSizes:  nil,
+			wantWrites: nil,
+			wantLevels: 0,
+		},
+		{
+			name:      "truncated row missing trailing columns",
+			raw:       "   0   |          1 |         0.40000\n",
+			wantSizes: nil,
+		},
+		{
+			name:    "malformed size value on an otherwise well-formed row",
+			raw:     "   0   |          1 |         1.2.3 |        0.00000 |        0.00000 |        0.40000\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sizes, _, _, writes, err := parseLevelStats(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(sizes) != tt.wantLevels {
+				t.Fatalf("got %d levels, want %d", len(sizes), tt.wantLevels)
+			}
+
+			for lvl, want := range tt.wantSizes {
+				if sizes[lvl] != want {
+					t.Errorf("level %d size = %d, want %d", lvl, sizes[lvl], want)
+				}
+			}
+
+			for lvl, want := range tt.wantWrites {
+				if writes[lvl] != want {
+					t.Errorf("level %d write = %d, want %d", lvl, writes[lvl], want)
+				}
+			}
+		})
+	}
+}
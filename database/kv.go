@@ -0,0 +1,106 @@
+package database
+
+// Batch buffers a set of writes and commits them to the underlying store in
+// a single call, so callers such as the trie/state writer stop paying one
+// syscall per key.
+type Batch interface {
+	// Put stages a key-value pair for writing.
+	Put(key, value []byte)
+
+	// Delete stages a key for removal.
+	Delete(key []byte)
+
+	// Write commits every staged operation atomically.
+	Write() error
+}
+
+// Iterator walks the key-value pairs of a KV store in key order.
+type Iterator interface {
+	// Next advances the iterator and reports whether a pair is available.
+	Next() bool
+
+	// Key returns the key at the current iterator position.
+	Key() []byte
+
+	// Value returns the value at the current iterator position.
+	Value() []byte
+
+	// Release releases the iterator's resources. The iterator is not usable
+	// after calling Release.
+	Release()
+
+	// Error returns any accumulated error encountered during iteration.
+	Error() error
+}
+
+// KV is the backend-agnostic key-value store contract. Both the goleveldb
+// backend (levelDBKV) and the pebble backend (pebbleKV) satisfy it, so
+// callers such as blockchain/storage can select an implementation at
+// runtime without depending on either driver directly.
+type KV interface {
+	// Get retrieves the value for key. The second return value reports
+	// whether the key was found.
+	Get(key []byte) ([]byte, bool, error)
+
+	// Set stores the key-value pair.
+	Set(key []byte, value []byte) error
+
+	// NewBatch returns a Batch for grouping writes to this store.
+	NewBatch() Batch
+
+	// NewIterator returns an Iterator over this store's key space.
+	NewIterator() Iterator
+
+	// Close closes the underlying store.
+	Close() error
+
+	// Metrics returns a snapshot of the store's current disk/compaction
+	// counters, used by the metering goroutine to publish gauges without
+	// depending on a specific backend's stats API.
+	Metrics() (Metrics, error)
+}
+
+// Metrics is a backend-agnostic snapshot of cumulative disk and compaction
+// counters. The metering goroutine diffs successive snapshots to produce
+// the per-tick gauges, so backends only need to report cumulative totals.
+type Metrics struct {
+	// DiskSize is the total size, in bytes, of all levels/sstables.
+	DiskSize int64
+
+	// IORead and IOWrite are cumulative bytes read from and written to disk.
+	IORead  int64
+	IOWrite int64
+
+	// WriteDelayCount and WriteDelayDuration track cumulative write stalls
+	// caused by compaction backpressure.
+	WriteDelayCount    int64
+	WriteDelayDuration int64
+
+	// WritePaused reports whether the store is currently blocking writes to
+	// let compaction catch up.
+	WritePaused bool
+
+	// DiskRead and DiskWrite are the backend driver's own cumulative disk
+	// I/O byte counters, distinct from IORead/IOWrite which come from a
+	// separate iostats property where the backend exposes one.
+	DiskRead  int64
+	DiskWrite int64
+
+	// MemCompactions, Level0Compactions, NonLevel0Compactions and
+	// SeekCompactions are cumulative counts of each compaction trigger kind.
+	MemCompactions       int64
+	Level0Compactions    int64
+	NonLevel0Compactions int64
+	SeekCompactions      int64
+
+	// LevelSizes, LevelTimes, LevelReads and LevelWrites are cumulative
+	// per-level counters, indexed by level number.
+	LevelSizes  []int64
+	LevelTimes  []int64
+	LevelReads  []int64
+	LevelWrites []int64
+
+	// LevelTableCounts is the cumulative sstable count per level, indexed
+	// by level number.
+	LevelTableCounts []int64
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+)
+
+// writePausedWarnInterval throttles the "database compacting, degraded
+// performance" warning to at most once per interval, so a sustained
+// compaction stall doesn't spam the log.
+const writePausedWarnInterval = time.Minute
+
+// MetricsSource is the minimal contract the metering goroutine needs from a
+// backend: a way to pull a cumulative Metrics snapshot. Every KV satisfies it, but a
+// backend can also drive metering through a lighter-weight adapter without
+// implementing the rest of KV.
+type MetricsSource interface {
+	Metrics() (Metrics, error)
+}
+
+// meter polls src.Metrics() on every tick, diffs it against the previous
+// snapshot and publishes the deltas as gauges plus an IOStats update to any
+// subscriber registered through SubscribeIOStats. It is backend-agnostic:
+// the same loop drives both the goleveldb and pebble backends.
+func meter(src MetricsSource, refresh time.Duration, name string, logger hclog.Logger) {
+	setGauge := func(metricName string, value int64) {
+		metrics.SetGauge([]string{"leveldb", name, metricName}, float32(value))
+	}
+
+	logger.Info("started metering", "name", name, "refresh", refresh.String())
+
+	var (
+		previous        Metrics
+		lastWritePaused time.Time
+	)
+
+	timer := time.NewTimer(refresh)
+	defer timer.Stop()
+
+	for i := 1; ; i++ {
+		current, err := src.Metrics()
+		if err != nil {
+			logger.Error("failed to read database metrics", "err", err.Error())
+			break
+		}
+
+		if i > 1 {
+			setGauge("diskSize", current.DiskSize)
+			setGauge("compactTime", sum(current.LevelTimes)-sum(previous.LevelTimes))
+			setGauge("compactRead", sum(current.LevelReads)-sum(previous.LevelReads))
+			setGauge("compactWrite", sum(current.LevelWrites)-sum(previous.LevelWrites))
+		}
+
+		delayN := current.WriteDelayCount - previous.WriteDelayCount
+		delayDuration := current.WriteDelayDuration - previous.WriteDelayDuration
+
+		setGauge("writeDelayNMeter", delayN)
+		setGauge("writeDelayMeter", delayDuration)
+
+		// If a warning that the db is performing compaction has been
+		// displayed, any subsequent warnings will be withheld for one
+		// minute not to overwhelm the user.
+		if current.WritePaused && delayN == 0 && delayDuration == 0 &&
+			time.Now().After(lastWritePaused.Add(writePausedWarnInterval)) {
+			logger.Warn("database compacting, degraded performance")
+			lastWritePaused = time.Now()
+		}
+
+		readDelta := current.IORead - previous.IORead
+		writeDelta := current.IOWrite - previous.IOWrite
+		setGauge("ioRead", readDelta)
+		setGauge("ioWrite", writeDelta)
+
+		setGauge("diskRead", current.DiskRead-previous.DiskRead)
+		setGauge("diskWrite", current.DiskWrite-previous.DiskWrite)
+
+		setGauge("memCompaction", current.MemCompactions)
+		setGauge("level0Compaction", current.Level0Compactions)
+		setGauge("nonlevel0Compaction", current.NonLevel0Compactions)
+		setGauge("seekCompaction", current.SeekCompactions)
+
+		for lvl, tables := range current.LevelTableCounts {
+			setGauge(fmt.Sprintf("level_%d_tableCount", lvl), tables)
+		}
+
+		levelSizes := make([]int64, len(current.LevelSizes))
+		levelTimes := make([]int64, len(current.LevelTimes))
+		levelReads := make([]int64, len(current.LevelReads))
+		levelWrites := make([]int64, len(current.LevelWrites))
+
+		for lvl := range current.LevelSizes {
+			levelSizes[lvl] = current.LevelSizes[lvl]
+			setGauge(fmt.Sprintf("level_%d_size", lvl), levelSizes[lvl])
+		}
+
+		for lvl := range current.LevelTimes {
+			levelTimes[lvl] = current.LevelTimes[lvl] - deltaAt(previous.LevelTimes, lvl)
+			setGauge(fmt.Sprintf("level_%d_time", lvl), levelTimes[lvl])
+		}
+
+		for lvl := range current.LevelReads {
+			levelReads[lvl] = current.LevelReads[lvl] - deltaAt(previous.LevelReads, lvl)
+			setGauge(fmt.Sprintf("level_%d_read", lvl), levelReads[lvl])
+		}
+
+		for lvl := range current.LevelWrites {
+			levelWrites[lvl] = current.LevelWrites[lvl] - deltaAt(previous.LevelWrites, lvl)
+			setGauge(fmt.Sprintf("level_%d_write", lvl), levelWrites[lvl])
+		}
+
+		publishIOStats(IOStats{
+			Name:        name,
+			IORead:      readDelta,
+			IOWrite:     writeDelta,
+			LevelSizes:  levelSizes,
+			LevelTimes:  levelTimes,
+			LevelReads:  levelReads,
+			LevelWrites: levelWrites,
+		})
+
+		logger.Debug("meter tick",
+			"name", name,
+			"diskSize", current.DiskSize,
+			"ioRead", readDelta,
+			"ioWrite", writeDelta,
+			"levels", len(current.LevelSizes),
+		)
+
+		previous = current
+
+		select {
+		case <-timer.C:
+			timer.Reset(refresh)
+		}
+	}
+}
+
+func sum(values []int64) int64 {
+	var total int64
+	for _, v := range values {
+		total += v
+	}
+
+	return total
+}
+
+func deltaAt(values []int64, index int) int64 {
+	if index >= len(values) {
+		return 0
+	}
+
+	return values[index]
+}
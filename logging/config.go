@@ -0,0 +1,20 @@
+package logging
+
+// Config configures the root Logger constructed by New.
+type Config struct {
+	// Format selects the output encoding: "json", "logfmt" or "console".
+	// Defaults to "logfmt" when empty.
+	Format string
+
+	// Level is the default minimum level applied to subsystems that have no
+	// entry in Levels. Defaults to "info" when empty.
+	Level string
+
+	// File, if set, appends logs to this path instead of writing to stderr.
+	File string
+
+	// Levels overrides the minimum level per subsystem name, e.g.
+	// {"p2p": "debug", "txpool": "info"}. Subsystem names match the dotted
+	// name built up by successive calls to Named.
+	Levels map[string]string
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// DebugLevelHandler returns an http.Handler meant to be mounted at
+// /debug/loglevel on the existing JSON-RPC admin listener. GET returns the
+// current per-subsystem levels; POST with a JSON body of
+// {"subsystem": "p2p", "level": "debug"} changes one at runtime, without a
+// restart.
+func (l *Logger) DebugLevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(l.SubsystemLevels())
+		case http.MethodPost:
+			l.handleSetLevel(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (l *Logger) handleSetLevel(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Subsystem string `json:"subsystem"`
+		Level     string `json:"level"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if req.Subsystem == "" {
+		http.Error(w, "subsystem is required", http.StatusBadRequest)
+
+		return
+	}
+
+	l.levels.set(req.Subsystem, parseLevel(req.Level))
+	w.WriteHeader(http.StatusNoContent)
+}
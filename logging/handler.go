@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// subsystemKey is the slog attribute key a Named logger stamps onto every
+// record, so levelHandler can look up its per-subsystem level override.
+const subsystemKey = "subsystem"
+
+// levelHandler wraps a slog.Handler and filters records by the minimum
+// level configured for their "subsystem" attribute, falling back to a
+// default level when the subsystem has no override. It lets a single
+// process-wide handler enforce "log_levels: {p2p: debug, txpool: info}"
+// style per-subsystem overrides instead of one global level.
+type levelHandler struct {
+	next         slog.Handler
+	defaultLevel slog.Level
+	levels       *subsystemLevels
+	subsystem    string
+}
+
+func newLevelHandler(next slog.Handler, defaultLevel slog.Level, levels *subsystemLevels) *levelHandler {
+	return &levelHandler{next: next, defaultLevel: defaultLevel, levels: levels}
+}
+
+// Enabled implements slog.Handler.
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	threshold := h.defaultLevel
+	if lvl, ok := h.levels.get(h.subsystem); ok {
+		threshold = lvl
+	}
+
+	return level >= threshold
+}
+
+// Handle implements slog.Handler.
+func (h *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler, tracking the "subsystem" attribute so
+// Enabled can look up the right level override.
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+
+	for _, attr := range attrs {
+		if attr.Key == subsystemKey {
+			clone.subsystem = attr.Value.String()
+		}
+	}
+
+	return &clone
+}
+
+// WithGroup implements slog.Handler.
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+
+	return &clone
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+// discardHandler is a no-op slog.Handler used as levelHandler's next so
+// these tests only exercise level-filtering/subsystem propagation.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return true }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+func TestLevelHandlerEnabledDefaultLevel(t *testing.T) {
+	levels := newSubsystemLevels(nil)
+	h := newLevelHandler(discardHandler{}, slog.LevelInfo, levels)
+
+	tests := []struct {
+		name  string
+		level slog.Level
+		want  bool
+	}{
+		{name: "below default is disabled", level: slog.LevelDebug, want: false},
+		{name: "at default is enabled", level: slog.LevelInfo, want: true},
+		{name: "above default is enabled", level: slog.LevelError, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.Enabled(context.Background(), tt.level); got != tt.want {
+				t.Errorf("Enabled(%v) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelHandlerEnabledSubsystemOverride(t *testing.T) {
+	levels := newSubsystemLevels(map[string]slog.Level{"p2p": slog.LevelDebug})
+	h := newLevelHandler(discardHandler{}, slog.LevelInfo, levels)
+
+	withSubsystem := h.WithAttrs([]slog.Attr{slog.String(subsystemKey, "p2p")})
+
+	if !withSubsystem.Enabled(context.Background(), slog.LevelDebug) {
+		t.Errorf("expected subsystem override to enable Debug for p2p")
+	}
+
+	// A different, unconfigured subsystem still falls back to the default.
+	withOther := h.WithAttrs([]slog.Attr{slog.String(subsystemKey, "txpool")})
+
+	if withOther.Enabled(context.Background(), slog.LevelDebug) {
+		t.Errorf("expected unconfigured subsystem to fall back to the default level")
+	}
+}
+
+func TestLevelHandlerWithAttrsIgnoresUnrelatedAttrs(t *testing.T) {
+	levels := newSubsystemLevels(nil)
+	h := newLevelHandler(discardHandler{}, slog.LevelInfo, levels)
+
+	clone := h.WithAttrs([]slog.Attr{slog.String("other", "value")}).(*levelHandler)
+
+	if clone.subsystem != "" {
+		t.Errorf("subsystem = %q, want empty when no subsystem attr is set", clone.subsystem)
+	}
+}
+
+func TestLevelHandlerWithAttrsDoesNotMutateParent(t *testing.T) {
+	levels := newSubsystemLevels(map[string]slog.Level{"p2p": slog.LevelDebug})
+	h := newLevelHandler(discardHandler{}, slog.LevelInfo, levels)
+
+	_ = h.WithAttrs([]slog.Attr{slog.String(subsystemKey, "p2p")})
+
+	if h.subsystem != "" {
+		t.Errorf("parent handler's subsystem was mutated to %q", h.subsystem)
+	}
+}
+
+func TestLevelHandlerDynamicLevelChangeTakesEffect(t *testing.T) {
+	levels := newSubsystemLevels(nil)
+	h := newLevelHandler(discardHandler{}, slog.LevelInfo, levels)
+
+	withSubsystem := h.WithAttrs([]slog.Attr{slog.String(subsystemKey, "txpool")})
+
+	if withSubsystem.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatalf("expected Debug to be disabled before the level change")
+	}
+
+	levels.set("txpool", slog.LevelDebug)
+
+	if !withSubsystem.Enabled(context.Background(), slog.LevelDebug) {
+		t.Errorf("expected Debug to be enabled after the txpool level change")
+	}
+}
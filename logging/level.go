@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// levelTrace extends slog's level range downward to make room for
+// hclog.Trace, which slog has no native equivalent for.
+const levelTrace slog.Level = slog.LevelDebug - 4
+
+// parseLevel converts a config string ("trace", "debug", "info", "warn",
+// "error") into a slog.Level, defaulting to Info for anything unrecognized.
+func parseLevel(s string) slog.Level {
+	switch strings.ToLower(s) {
+	case "trace":
+		return levelTrace
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// hclogLevel converts a slog.Level back into its closest hclog.Level, for
+// GetLevel and SetLevel callers that still think in hclog terms.
+func hclogLevel(level slog.Level) hclog.Level {
+	switch {
+	case level <= levelTrace:
+		return hclog.Trace
+	case level <= slog.LevelDebug:
+		return hclog.Debug
+	case level <= slog.LevelInfo:
+		return hclog.Info
+	case level <= slog.LevelWarn:
+		return hclog.Warn
+	default:
+		return hclog.Error
+	}
+}
+
+// slogLevel converts an hclog.Level into its closest slog.Level.
+func slogLevel(level hclog.Level) slog.Level {
+	switch level {
+	case hclog.Trace:
+		return levelTrace
+	case hclog.Debug:
+		return slog.LevelDebug
+	case hclog.Warn:
+		return slog.LevelWarn
+	case hclog.Error:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// subsystemLevels is a concurrency-safe map of per-subsystem minimum log
+// levels, shared between the level-filtering slog.Handler and the runtime
+// level-change API exposed over /debug/loglevel.
+type subsystemLevels struct {
+	mu     sync.RWMutex
+	levels map[string]slog.Level
+}
+
+func newSubsystemLevels(initial map[string]slog.Level) *subsystemLevels {
+	levels := make(map[string]slog.Level, len(initial))
+	for name, level := range initial {
+		levels[name] = level
+	}
+
+	return &subsystemLevels{levels: levels}
+}
+
+func (s *subsystemLevels) get(name string) (slog.Level, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	level, ok := s.levels[name]
+
+	return level, ok
+}
+
+func (s *subsystemLevels) set(name string, level slog.Level) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.levels[name] = level
+}
+
+func (s *subsystemLevels) snapshot() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]string, len(s.levels))
+	for name, level := range s.levels {
+		out[name] = level.String()
+	}
+
+	return out
+}
@@ -0,0 +1,198 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Logger is a thin hclog.Logger facade over the stdlib log/slog package. It
+// lets server, profiling, database and blockchain/storage/leveldb keep
+// depending on hclog.Logger while the actual encoding, output and
+// per-subsystem level filtering happen in slog underneath. This is a
+// migration shim: new code should prefer slog directly once every caller
+// has moved off hclog.
+type Logger struct {
+	slog   *slog.Logger
+	name   string
+	levels *subsystemLevels
+	writer io.Writer
+}
+
+// New constructs the root Logger from cfg.
+func New(cfg Config) (*Logger, error) {
+	w, err := openWriter(cfg.File)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultLevel := parseLevel(cfg.Level)
+
+	initial := make(map[string]slog.Level, len(cfg.Levels))
+	for name, level := range cfg.Levels {
+		initial[name] = parseLevel(level)
+	}
+
+	levels := newSubsystemLevels(initial)
+
+	base, err := newBaseHandler(cfg.Format, w)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := newLevelHandler(base, defaultLevel, levels)
+
+	return &Logger{
+		slog:   slog.New(handler),
+		levels: levels,
+		writer: w,
+	}, nil
+}
+
+func openWriter(path string) (io.Writer, error) {
+	if path == "" {
+		return os.Stderr, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %q: %w", path, err)
+	}
+
+	return f, nil
+}
+
+func newBaseHandler(format string, w io.Writer) (slog.Handler, error) {
+	// Every level is handled by levelHandler above this one; the base
+	// handler just encodes, so it must never filter on its own.
+	opts := &slog.HandlerOptions{Level: levelTrace}
+
+	switch strings.ToLower(format) {
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	case "logfmt", "console", "":
+		return slog.NewTextHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+func (l *Logger) log(level slog.Level, msg string, args ...interface{}) {
+	l.slog.Log(context.Background(), level, msg, args...)
+}
+
+// Log implements hclog.Logger.
+func (l *Logger) Log(level hclog.Level, msg string, args ...interface{}) {
+	l.log(slogLevel(level), msg, args...)
+}
+
+// Trace implements hclog.Logger.
+func (l *Logger) Trace(msg string, args ...interface{}) { l.log(levelTrace, msg, args...) }
+
+// Debug implements hclog.Logger.
+func (l *Logger) Debug(msg string, args ...interface{}) { l.log(slog.LevelDebug, msg, args...) }
+
+// Info implements hclog.Logger.
+func (l *Logger) Info(msg string, args ...interface{}) { l.log(slog.LevelInfo, msg, args...) }
+
+// Warn implements hclog.Logger.
+func (l *Logger) Warn(msg string, args ...interface{}) { l.log(slog.LevelWarn, msg, args...) }
+
+// Error implements hclog.Logger.
+func (l *Logger) Error(msg string, args ...interface{}) { l.log(slog.LevelError, msg, args...) }
+
+// IsTrace implements hclog.Logger.
+func (l *Logger) IsTrace() bool { return l.slog.Enabled(context.Background(), levelTrace) }
+
+// IsDebug implements hclog.Logger.
+func (l *Logger) IsDebug() bool { return l.slog.Enabled(context.Background(), slog.LevelDebug) }
+
+// IsInfo implements hclog.Logger.
+func (l *Logger) IsInfo() bool { return l.slog.Enabled(context.Background(), slog.LevelInfo) }
+
+// IsWarn implements hclog.Logger.
+func (l *Logger) IsWarn() bool { return l.slog.Enabled(context.Background(), slog.LevelWarn) }
+
+// IsError implements hclog.Logger.
+func (l *Logger) IsError() bool { return l.slog.Enabled(context.Background(), slog.LevelError) }
+
+// ImpliedArgs implements hclog.Logger. slog does not expose accumulated
+// attributes, so this always returns nil.
+func (l *Logger) ImpliedArgs() []interface{} { return nil }
+
+// With implements hclog.Logger.
+func (l *Logger) With(args ...interface{}) hclog.Logger {
+	clone := *l
+	clone.slog = l.slog.With(args...)
+
+	return &clone
+}
+
+// Name implements hclog.Logger.
+func (l *Logger) Name() string { return l.name }
+
+// Named implements hclog.Logger, stamping the dotted subsystem name onto
+// every record so per-subsystem level overrides and JSON/Loki queries can
+// key off it.
+func (l *Logger) Named(name string) hclog.Logger {
+	full := name
+	if l.name != "" {
+		full = l.name + "." + name
+	}
+
+	return l.ResetNamed(full)
+}
+
+// ResetNamed implements hclog.Logger.
+func (l *Logger) ResetNamed(name string) hclog.Logger {
+	clone := *l
+	clone.name = name
+	clone.slog = l.slog.With(subsystemKey, name)
+
+	return &clone
+}
+
+// SetLevel implements hclog.Logger, changing the minimum level for this
+// logger's subsystem at runtime. The underlying map is shared with every
+// derived logger, so the change applies to every logger with this name.
+func (l *Logger) SetLevel(level hclog.Level) {
+	l.levels.set(l.name, slogLevel(level))
+}
+
+// GetLevel implements hclog.Logger.
+func (l *Logger) GetLevel() hclog.Level {
+	if level, ok := l.levels.get(l.name); ok {
+		return hclogLevel(level)
+	}
+
+	return hclog.NoLevel
+}
+
+// StandardLogger implements hclog.Logger.
+func (l *Logger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	return log.New(l.StandardWriter(opts), "", 0)
+}
+
+// StandardWriter implements hclog.Logger.
+func (l *Logger) StandardWriter(_ *hclog.StandardLoggerOptions) io.Writer {
+	return l.writer
+}
+
+// SetSubsystemLevel changes the minimum level for an arbitrary subsystem
+// name, regardless of which logger instance is holding the map, so a caller
+// can change levels for subsystems it has no direct Logger handle for.
+func (l *Logger) SetSubsystemLevel(name string, level hclog.Level) {
+	l.levels.set(name, slogLevel(level))
+}
+
+// SubsystemLevels returns a snapshot of every subsystem's current minimum
+// level, keyed by subsystem name.
+func (l *Logger) SubsystemLevels() map[string]string {
+	return l.levels.snapshot()
+}
@@ -0,0 +1,85 @@
+package profiling
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	ddprofiler "gopkg.in/DataDog/dd-trace-go.v1/profiler"
+)
+
+// dataDogProfiler drives continuous profiling and tracing through a DataDog
+// agent.
+type dataDogProfiler struct {
+	agentAddr string
+	logger    hclog.Logger
+
+	mu      sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+}
+
+func newDataDogProfiler(cfg Config) Profiler {
+	return &dataDogProfiler{agentAddr: cfg.ProfilerServer, logger: cfg.Logger}
+}
+
+// Start kicks off a background retry loop that connects to the DataDog
+// agent and returns immediately; it no longer blocks on the agent being
+// reachable at boot.
+func (p *dataDogProfiler) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p.mu.Lock()
+	p.cancel = cancel
+	p.mu.Unlock()
+
+	startWithRetry(ctx, p.logger, func() error {
+		if err := ddprofiler.Start(
+			// enable all profiles
+			ddprofiler.WithProfileTypes(
+				ddprofiler.CPUProfile,
+				ddprofiler.HeapProfile,
+				ddprofiler.BlockProfile,
+				ddprofiler.MutexProfile,
+				ddprofiler.GoroutineProfile,
+				ddprofiler.MetricsProfile,
+			),
+			ddprofiler.WithAgentAddr(p.agentAddr),
+		); err != nil {
+			return fmt.Errorf("could not start datadog profiler: %w", err)
+		}
+
+		tracer.Start()
+
+		p.mu.Lock()
+		p.started = true
+		p.mu.Unlock()
+
+		return nil
+	})
+
+	return nil
+}
+
+// Stop cancels the retry loop and, if a connection was ever established,
+// stops the profiler and tracer. It is safe to call even if Start never
+// succeeded.
+func (p *dataDogProfiler) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	if !p.started {
+		return nil
+	}
+
+	ddprofiler.Stop()
+	tracer.Stop()
+
+	return nil
+}
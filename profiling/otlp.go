@@ -0,0 +1,97 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/pprof"
+	"time"
+)
+
+// otlpCaptureInterval is how often the OTLP profiler captures and ships a
+// CPU profile. The pprof-over-OTLP profile signal is still experimental
+// upstream, so this implementation intentionally stays minimal: periodic
+// CPU profiles shipped as opaque pprof payloads.
+const otlpCaptureInterval = 30 * time.Second
+
+// otlpProfiler periodically captures pprof profiles and ships them to an
+// OTLP collector's experimental profiles endpoint.
+type otlpProfiler struct {
+	endpoint string
+	client   *http.Client
+	cancel   context.CancelFunc
+}
+
+func newOTLPProfiler(cfg Config) Profiler {
+	return &otlpProfiler{
+		endpoint: cfg.ProfilerServer,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start begins the periodic capture loop in the background.
+func (p *otlpProfiler) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	go p.run(ctx)
+
+	return nil
+}
+
+// Stop cancels the capture loop.
+func (p *otlpProfiler) Stop() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	return nil
+}
+
+func (p *otlpProfiler) run(ctx context.Context) {
+	ticker := time.NewTicker(otlpCaptureInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = p.captureAndSend(ctx)
+		}
+	}
+}
+
+func (p *otlpProfiler) captureAndSend(ctx context.Context) error {
+	var buf bytes.Buffer
+
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		return fmt.Errorf("failed to start cpu profile: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		pprof.StopCPUProfile()
+
+		return ctx.Err()
+	case <-time.After(time.Second):
+	}
+
+	pprof.StopCPUProfile()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/v1development/profiles", &buf)
+	if err != nil {
+		return fmt.Errorf("failed to build otlp profile request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/vnd.pprof")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send otlp profile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -1,84 +1,60 @@
 package profiling
 
 import (
-	"os"
-	"runtime"
+	"fmt"
 
-	"github.com/grafana/pyroscope-go"
+	"github.com/hashicorp/go-hclog"
 )
 
+// Profiler is a continuous profiling strategy. Implementations ship process
+// profiles (CPU, heap, goroutines, ...) to an external agent or collector.
 type Profiler interface {
 	Start() error
 	Stop() error
 }
 
-type profiler struct {
-	prof  *pyroscope.Profiler
-	pConf pyroscope.Config
-}
-
-func NewProfiler(profilerServer, appName string, opts ...func(config *pyroscope.Config)) Profiler {
-	// These 2 lines are only required if you're using mutex or block profiling
-	// Read the explanation below for how to set these rates:
-	runtime.SetMutexProfileFraction(5)
-	runtime.SetBlockProfileRate(5)
-
-	pConf := pyroscope.Config{
-		ApplicationName: appName,
-
-		// replace this with the address of pyroscope server
-		ServerAddress: profilerServer,
-
-		// you can disable logging by setting this to nil
-		Logger: pyroscope.StandardLogger,
-
-		// you can provide static tags via a map:
-		Tags: map[string]string{"hostname": os.Getenv("HOSTNAME")},
-
-		ProfileTypes: []pyroscope.ProfileType{
-			// these profile types are enabled by default:
-			pyroscope.ProfileCPU,
-			pyroscope.ProfileAllocObjects,
-			pyroscope.ProfileAllocSpace,
-			pyroscope.ProfileInuseObjects,
-			pyroscope.ProfileInuseSpace,
+// Name identifies a Profiler implementation, selectable through the
+// --telemetry.profiler flag or the POLYGON_EDGE_PROFILER env var.
+type Name string
 
-			// these profile types are optional:
-			pyroscope.ProfileGoroutines,
-			pyroscope.ProfileMutexCount,
-			pyroscope.ProfileMutexDuration,
-			pyroscope.ProfileBlockCount,
-			pyroscope.ProfileBlockDuration,
-		},
-	}
+const (
+	Pyroscope    Name = "pyroscope"
+	DataDog      Name = "datadog"
+	OTLPProfiles Name = "otlp-profiles"
+)
 
-	for _, f := range opts {
-		f(&pConf)
-	}
+// Config bundles the parameters shared by every Profiler implementation.
+type Config struct {
+	// AppName identifies this process to the profiling backend.
+	AppName string
 
-	return &profiler{
-		pConf: pConf,
-	}
-}
+	// ProfilerServer is the address of the profiling agent/collector.
+	ProfilerServer string
 
-// Start starts the profiler
-func (p *profiler) Start() error {
-	var err error
-	p.prof, err = pyroscope.Start(p.pConf)
+	// Tags are static key/value labels attached to every profile.
+	Tags map[string]string
 
-	return err
+	// Logger receives the Warn/Info lines emitted by the start retry loop.
+	// A discard logger is used if nil.
+	Logger hclog.Logger
 }
 
-// Stop stops the profiler
-func (p *profiler) Stop() error {
-	return p.prof.Stop()
-}
+// New constructs the Profiler implementation identified by name. An empty
+// name defaults to Pyroscope, matching the profiler polygon-edge has
+// historically shipped with.
+func New(name Name, cfg Config) (Profiler, error) {
+	if cfg.Logger == nil {
+		cfg.Logger = hclog.NewNullLogger()
+	}
 
-// WithTags merges user defined tags with default tags
-func WithTags(tags map[string]string) func(config *pyroscope.Config) {
-	return func(c *pyroscope.Config) {
-		for k, v := range tags {
-			c.Tags[k] = v
-		}
+	switch name {
+	case Pyroscope, "":
+		return newPyroscopeProfiler(cfg), nil
+	case DataDog:
+		return newDataDogProfiler(cfg), nil
+	case OTLPProfiles:
+		return newOTLPProfiler(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown profiler %q", name)
 	}
 }
@@ -0,0 +1,106 @@
+package profiling
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/grafana/pyroscope-go"
+	"github.com/hashicorp/go-hclog"
+)
+
+// pyroscopeProfiler drives continuous profiling through a Pyroscope agent.
+type pyroscopeProfiler struct {
+	pConf  pyroscope.Config
+	logger hclog.Logger
+
+	mu     sync.Mutex
+	prof   *pyroscope.Profiler
+	cancel context.CancelFunc
+}
+
+func newPyroscopeProfiler(cfg Config) Profiler {
+	// These 2 lines are only required if you're using mutex or block profiling
+	// Read the explanation below for how to set these rates:
+	runtime.SetMutexProfileFraction(5)
+	runtime.SetBlockProfileRate(5)
+
+	tags := map[string]string{"hostname": os.Getenv("HOSTNAME")}
+	for k, v := range cfg.Tags {
+		tags[k] = v
+	}
+
+	pConf := pyroscope.Config{
+		ApplicationName: cfg.AppName,
+
+		// replace this with the address of pyroscope server
+		ServerAddress: cfg.ProfilerServer,
+
+		// you can disable logging by setting this to nil
+		Logger: pyroscope.StandardLogger,
+
+		Tags: tags,
+
+		ProfileTypes: []pyroscope.ProfileType{
+			// these profile types are enabled by default:
+			pyroscope.ProfileCPU,
+			pyroscope.ProfileAllocObjects,
+			pyroscope.ProfileAllocSpace,
+			pyroscope.ProfileInuseObjects,
+			pyroscope.ProfileInuseSpace,
+
+			// these profile types are optional:
+			pyroscope.ProfileGoroutines,
+			pyroscope.ProfileMutexCount,
+			pyroscope.ProfileMutexDuration,
+			pyroscope.ProfileBlockCount,
+			pyroscope.ProfileBlockDuration,
+		},
+	}
+
+	return &pyroscopeProfiler{pConf: pConf, logger: cfg.Logger}
+}
+
+// Start kicks off a background retry loop that connects to the Pyroscope
+// agent and returns immediately; it no longer blocks on the agent being
+// reachable at boot.
+func (p *pyroscopeProfiler) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p.mu.Lock()
+	p.cancel = cancel
+	p.mu.Unlock()
+
+	startWithRetry(ctx, p.logger, func() error {
+		prof, err := pyroscope.Start(p.pConf)
+		if err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		p.prof = prof
+		p.mu.Unlock()
+
+		return nil
+	})
+
+	return nil
+}
+
+// Stop cancels the retry loop and, if a connection was ever established,
+// stops the profiler. It is safe to call even if Start never succeeded.
+func (p *pyroscopeProfiler) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	if p.prof == nil {
+		return nil
+	}
+
+	return p.prof.Stop()
+}
@@ -0,0 +1,41 @@
+package profiling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestPyroscopeProfilerStopBeforeStart(t *testing.T) {
+	p := &pyroscopeProfiler{logger: hclog.NewNullLogger()}
+
+	if err := p.Stop(); err != nil {
+		t.Fatalf("unexpected error stopping a profiler that was never started: %v", err)
+	}
+}
+
+func TestPyroscopeProfilerStopBeforeConnectionEstablished(t *testing.T) {
+	p := &pyroscopeProfiler{logger: hclog.NewNullLogger()}
+
+	// Mirrors the state after Start has kicked off the retry goroutine but
+	// before it has ever connected: cancel is set, prof is still nil.
+	_, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	if err := p.Stop(); err != nil {
+		t.Fatalf("unexpected error stopping a profiler with no established connection: %v", err)
+	}
+}
+
+func TestPyroscopeProfilerStopIsIdempotent(t *testing.T) {
+	p := &pyroscopeProfiler{logger: hclog.NewNullLogger()}
+
+	if err := p.Stop(); err != nil {
+		t.Fatalf("unexpected error on first Stop: %v", err)
+	}
+
+	if err := p.Stop(); err != nil {
+		t.Fatalf("unexpected error on second Stop: %v", err)
+	}
+}
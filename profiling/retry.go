@@ -0,0 +1,49 @@
+package profiling
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/go-hclog"
+)
+
+// retryInitialInterval, retryMultiplier and retryMaxInterval bound the
+// exponential-backoff-with-jitter loop profiler strategies use to connect
+// to their agent at startup: start at 1s, double each attempt, cap at 2m,
+// and keep retrying indefinitely.
+const (
+	retryInitialInterval = time.Second
+	retryMultiplier      = 2
+	retryMaxInterval     = 2 * time.Minute
+)
+
+// startWithRetry runs start in a background goroutine, retrying on error
+// with exponential backoff and jitter until it succeeds or ctx is
+// cancelled. This keeps a briefly-unreachable profiling agent at boot
+// (common when a sidecar starts after the main container) from silently
+// disabling profiling for the rest of the process lifetime.
+func startWithRetry(ctx context.Context, logger hclog.Logger, start func() error) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = retryInitialInterval
+	b.Multiplier = retryMultiplier
+	b.MaxInterval = retryMaxInterval
+	b.MaxElapsedTime = 0 // retry indefinitely
+
+	bctx := backoff.WithContext(b, ctx)
+
+	attempt := 0
+	notify := func(err error, next time.Duration) {
+		attempt++
+		logger.Warn("profiler failed to start, retrying", "attempt", attempt, "nextDelay", next.String(), "err", err.Error())
+	}
+
+	go func() {
+		if err := backoff.RetryNotify(start, bctx, notify); err != nil {
+			// ctx was cancelled (Stop called) before the connection ever succeeded
+			return
+		}
+
+		logger.Info("profiler connection established")
+	}()
+}
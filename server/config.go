@@ -0,0 +1,42 @@
+package server
+
+import "flag"
+
+// Config holds the settings a Server is constructed and started from.
+type Config struct {
+	// DataDir is the path blockchain storage is opened under.
+	DataDir string
+
+	// DBBackend selects which KV backend blockchain storage opens, set via
+	// --db-backend: "leveldb" (default) or "pebble".
+	DBBackend string
+
+	// Profiler selects which profiling.Profiler implementation
+	// startTelemetry wires up, set via --telemetry.profiler. Falls back to
+	// the legacy POLYGON_EDGE_PROFILER env var when empty.
+	Profiler string
+
+	// LogFormat selects the log encoding: "json", "logfmt" or "console".
+	LogFormat string
+
+	// LogLevel is the default minimum log level applied to subsystems with
+	// no entry in LogLevels.
+	LogLevel string
+
+	// LogFilePath, if set, appends logs to this path instead of stderr.
+	LogFilePath string
+
+	// LogLevels overrides the minimum level per subsystem name, e.g.
+	// {"p2p": "debug", "txpool": "info"}.
+	LogLevels map[string]string
+}
+
+// RegisterFlags binds Config's CLI-settable fields onto fs, so the command
+// that builds the flag set only needs to call this once per Config.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.DBBackend, "db-backend", "leveldb", "key-value backend blockchain storage uses (leveldb, pebble)")
+	fs.StringVar(&c.Profiler, "telemetry.profiler", "", "continuous profiler to run (pyroscope, datadog, otlp-profiles)")
+	fs.StringVar(&c.LogFormat, "log-format", "logfmt", "log encoding (json, logfmt, console)")
+	fs.StringVar(&c.LogLevel, "log-level", "info", "default minimum log level")
+	fs.StringVar(&c.LogFilePath, "log-file", "", "append logs to this path instead of stderr")
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/profiling"
+	"github.com/0xPolygon/polygon-edge/telemetry"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Server owns the node's logging, storage, telemetry and profiling
+// lifecycle, built from a Config at startup.
+type Server struct {
+	config Config
+
+	logger   hclog.Logger
+	storage  storage.Storage
+	adminMux *http.ServeMux
+
+	telemetry *telemetry.Telemetry
+	profiler  profiling.Profiler
+}
+
+// NewServer builds a Server from config: it sets up structured logging,
+// opens blockchain storage on the selected backend, mounts the
+// /debug/loglevel endpoint, and starts metrics, traces and profiling
+// together.
+func NewServer(ctx context.Context, config Config) (*Server, error) {
+	s := &Server{config: config, adminMux: http.NewServeMux()}
+
+	if err := s.setupLogging(); err != nil {
+		return nil, err
+	}
+
+	store, err := s.setupStorage(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	s.storage = store
+
+	s.mountDebugRoutes(s.adminMux)
+
+	if err := s.startTelemetry(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// AdminMux returns the mux that NewServer mounted the /debug/loglevel
+// endpoint on, so the process's admin/JSON-RPC HTTP listener can serve it
+// alongside its own routes.
+func (s *Server) AdminMux() *http.ServeMux {
+	return s.adminMux
+}
+
+// Close shuts down telemetry and profiling and closes storage, in reverse
+// order of NewServer.
+func (s *Server) Close(ctx context.Context) error {
+	if err := s.stopTelemetry(ctx); err != nil {
+		s.logger.Warn("failed to stop telemetry", "err", err.Error())
+	}
+
+	return s.storage.Close()
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/0xPolygon/polygon-edge/logging"
+)
+
+// setupLogging builds the root logger from s.config's logging fields
+// (LogFormat, LogLevel, LogFilePath, LogLevels) and assigns it to s.logger,
+// so every downstream construction path (setupStorage, startTelemetry,
+// startProfiler) runs on the slog-backed logging.Logger instead of a raw
+// hclog implementation.
+func (s *Server) setupLogging() error {
+	logger, err := logging.New(logging.Config{
+		Format: s.config.LogFormat,
+		Level:  s.config.LogLevel,
+		File:   s.config.LogFilePath,
+		Levels: s.config.LogLevels,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger = logger
+
+	return nil
+}
+
+// mountDebugRoutes mounts logging's /debug/loglevel endpoint on mux, letting
+// operators inspect and change per-subsystem log levels at runtime without a
+// restart. It is a no-op if s.logger isn't a *logging.Logger (e.g. a caller
+// supplied its own hclog.Logger), so mounting it is always safe.
+func (s *Server) mountDebugRoutes(mux *http.ServeMux) {
+	logger, ok := s.logger.(*logging.Logger)
+	if !ok {
+		return
+	}
+
+	mux.Handle("/debug/loglevel", logger.DebugLevelHandler())
+}
@@ -1,19 +1,31 @@
 package server
 
 import (
+	"context"
 	"fmt"
-	"github.com/0xPolygon/polygon-edge/profiling"
-	"github.com/0xPolygon/polygon-edge/versioning"
 	"os"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/profiling"
+	"github.com/0xPolygon/polygon-edge/telemetry"
+	"github.com/0xPolygon/polygon-edge/versioning"
+
 	"github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
-	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
-	ddprofiler "gopkg.in/DataDog/dd-trace-go.v1/profiler"
 )
 
-func (s *Server) setupTelemetry() error {
+// telemetryProfilerEnv is the legacy fallback for selecting which
+// profiling.Profiler implementation startTelemetry wires up, used only when
+// --telemetry.profiler (s.config.Profiler) is unset. Accepted values mirror
+// profiling.Name: "pyroscope" (default), "datadog", "otlp-profiles".
+const telemetryProfilerEnv = "POLYGON_EDGE_PROFILER"
+
+// startTelemetry stands up metrics, traces and profiling together: the
+// existing armon/go-metrics + Prometheus sink, an optional OTLP exporter
+// driven by the standard OTEL_EXPORTER_OTLP_* env vars, and whichever
+// profiler strategy is selected through --telemetry.profiler /
+// POLYGON_EDGE_PROFILER.
+func (s *Server) startTelemetry(ctx context.Context) error {
 	inm := metrics.NewInmemSink(10*time.Second, time.Minute)
 	metrics.DefaultInmemSignal(inm)
 
@@ -22,89 +34,88 @@ func (s *Server) setupTelemetry() error {
 		return err
 	}
 
+	sinks := metrics.FanoutSink{inm, promSink}
+
+	otelTelemetry, err := telemetry.Start(ctx)
+	if err != nil {
+		return fmt.Errorf("could not start otel telemetry: %w", err)
+	}
+
+	if otelTelemetry != nil {
+		sinks = append(sinks, otelTelemetry.Sink())
+	}
+
+	s.telemetry = otelTelemetry
+
 	metricsConf := metrics.DefaultConfig("edge")
 	metricsConf.EnableHostname = false
-	metrics.NewGlobal(metricsConf, metrics.FanoutSink{
-		inm, promSink,
-	})
+	metrics.NewGlobal(metricsConf, sinks)
 
-	return nil
+	return s.startProfiler()
 }
 
-// enableDataDogProfiler enables DataDog profiler. Enable it by setting DD_ENABLE env var.
-// Additional parameters can be set with env vars (DD_) - https://docs.datadoghq.com/profiler/enabling/go/
-func (s *Server) enableDataDogProfiler() error {
-	if os.Getenv("DD_PROFILING_ENABLED") == "" {
-		s.logger.Debug("DataDog profiler disabled, set DD_PROFILING_ENABLED env var to enable it.")
-
-		return nil
-	}
-	// For containerized solutions, we want to be able to set the ip and port that the agent will bind to
-	// by defining DD_AGENT_HOST and DD_TRACE_AGENT_PORT env vars.
-	// If these env vars are not defined, the agent will bind to default ip:port ( localhost:8126 )
-	ddIP := "localhost"
-	ddPort := "8126"
-
-	if os.Getenv("DD_AGENT_HOST") != "" {
-		ddIP = os.Getenv("DD_AGENT_HOST")
+// stopTelemetry shuts down whatever startTelemetry started.
+func (s *Server) stopTelemetry(ctx context.Context) error {
+	if err := s.stopProfiler(); err != nil {
+		s.logger.Warn("failed to stop profiler", "err", err.Error())
 	}
 
-	if os.Getenv("DD_TRACE_AGENT_PORT") != "" {
-		ddPort = os.Getenv("DD_TRACE_AGENT_PORT")
-	}
+	return s.telemetry.Shutdown(ctx)
+}
 
-	if err := ddprofiler.Start(
-		// enable all profiles
-		ddprofiler.WithProfileTypes(
-			ddprofiler.CPUProfile,
-			ddprofiler.HeapProfile,
-			ddprofiler.BlockProfile,
-			ddprofiler.MutexProfile,
-			ddprofiler.GoroutineProfile,
-			ddprofiler.MetricsProfile,
-		),
-		ddprofiler.WithAgentAddr(ddIP+":"+ddPort),
-	); err != nil {
-		return fmt.Errorf("could not start datadog profiler: %w", err)
+// profilerName resolves the profiler strategy, preferring the
+// --telemetry.profiler flag (s.config.Profiler) over the legacy
+// POLYGON_EDGE_PROFILER env var so existing deployments keep working
+// unchanged until they migrate to the flag.
+func (s *Server) profilerName() string {
+	if s.config.Profiler != "" {
+		return s.config.Profiler
 	}
 
-	// start the tracer
-	tracer.Start()
-	s.logger.Info("DataDog profiler started")
-
-	return nil
+	return os.Getenv(telemetryProfilerEnv)
 }
 
-func (s *Server) closeDataDogProfiler() {
-	s.logger.Debug("closing DataDog profiler")
-	ddprofiler.Stop()
+// startProfiler resolves the profiler strategy named by --telemetry.profiler
+// and starts it.
+func (s *Server) startProfiler() error {
+	name := s.profilerName()
 
-	s.logger.Debug("closing DataDog tracer")
-	tracer.Stop()
-}
+	if os.Getenv("PROFILER_ENABLED") == "" && name == "" {
+		s.logger.Debug("profiling disabled, set PROFILER_ENABLED or --telemetry.profiler to enable it")
 
-func (s *Server) enableProfiler() error {
-	if os.Getenv("PROFILER_ENABLED") == "" {
-		s.logger.Debug("To enable profiling, set env var PROFILER_ENABLED to true")
 		return nil
 	}
 
 	pServer := os.Getenv("PROFILER_SERVER")
-
 	if pServer == "" {
-		return fmt.Errorf("profiling server not defined, define with PROFILER SERVER env var")
+		return fmt.Errorf("profiling server not defined, define with PROFILER_SERVER env var")
+	}
+
+	p, err := profiling.New(profiling.Name(name), profiling.Config{
+		AppName:        "polygon-edge",
+		ProfilerServer: pServer,
+		Logger:         s.logger.Named("profiler"),
+		Tags: map[string]string{
+			"commit_hash": versioning.Commit,
+			"version":     versioning.Version,
+			"branch":      versioning.Version,
+			"build_time":  versioning.BuildTime,
+		},
+	})
+	if err != nil {
+		return err
 	}
 
-	s.profiler = profiling.NewProfiler(pServer, "polygon-edge", profiling.WithTags(map[string]string{
-		"commit_hash": versioning.Commit,
-		"version":     versioning.Version,
-		"branch":      versioning.Version,
-		"build_time":  versioning.BuildTime,
-	}))
+	s.profiler = p
 
 	return s.profiler.Start()
 }
 
+// stopProfiler stops the active profiler, if any was started.
 func (s *Server) stopProfiler() error {
+	if s.profiler == nil {
+		return nil
+	}
+
 	return s.profiler.Stop()
 }
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+)
+
+// StorageBackend selects which KV backend blockchain storage is opened
+// with, configured through db_backend: leveldb|pebble.
+type StorageBackend string
+
+const (
+	LevelDBBackend StorageBackend = "leveldb"
+	PebbleBackend  StorageBackend = "pebble"
+)
+
+// setupStorage opens blockchain storage using the backend named by
+// s.config.DBBackend (leveldb by default, for backwards compatibility), so
+// operators hitting compaction stalls under high tx load can opt into
+// pebble's LSM implementation without any other code change.
+func (s *Server) setupStorage(path string) (storage.Storage, error) {
+	switch StorageBackend(s.config.DBBackend) {
+	case PebbleBackend:
+		return pebble.NewPebbleStorage(path, s.logger)
+	case LevelDBBackend, "":
+		return leveldb.NewLevelDBStorage(path, s.logger)
+	default:
+		return nil, fmt.Errorf("unknown db_backend %q", s.config.DBBackend)
+	}
+}
@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	gometrics "github.com/armon/go-metrics"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// goMetricsSink adapts an armon/go-metrics.MetricSink onto an OTel meter, so
+// existing instrumentation reaches the OTLP pipeline without being rewritten
+// against the OTel API directly. It implements gometrics.MetricSink.
+type goMetricsSink struct {
+	meter metric.Meter
+
+	mu         sync.Mutex
+	gauges     map[string]float64
+	counters   map[string]metric.Float64Counter
+	histograms map[string]metric.Float64Histogram
+}
+
+func newGoMetricsSink(provider metric.MeterProvider) *goMetricsSink {
+	return &goMetricsSink{
+		meter:      provider.Meter("polygon-edge/go-metrics"),
+		gauges:     make(map[string]float64),
+		counters:   make(map[string]metric.Float64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+}
+
+func metricName(key []string) string {
+	return strings.Join(key, ".")
+}
+
+// SetGauge implements gometrics.MetricSink.
+func (s *goMetricsSink) SetGauge(key []string, val float32) {
+	s.SetGaugeWithLabels(key, val, nil)
+}
+
+// SetGaugeWithLabels implements gometrics.MetricSink. Labels are currently
+// dropped: the OTel callback below only tracks a single value per key.
+func (s *goMetricsSink) SetGaugeWithLabels(key []string, val float32, _ []gometrics.Label) {
+	name := metricName(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, registered := s.gauges[name]; !registered {
+		_, _ = s.meter.Float64ObservableGauge(name,
+			metric.WithFloat64Callback(func(_ context.Context, obs metric.Float64Observer) error {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+
+				obs.Observe(s.gauges[name])
+
+				return nil
+			}),
+		)
+	}
+
+	s.gauges[name] = float64(val)
+}
+
+// EmitKey implements gometrics.MetricSink.
+func (s *goMetricsSink) EmitKey(key []string, val float32) {
+	s.SetGauge(key, val)
+}
+
+// IncrCounter implements gometrics.MetricSink.
+func (s *goMetricsSink) IncrCounter(key []string, val float32) {
+	s.IncrCounterWithLabels(key, val, nil)
+}
+
+// IncrCounterWithLabels implements gometrics.MetricSink.
+func (s *goMetricsSink) IncrCounterWithLabels(key []string, val float32, _ []gometrics.Label) {
+	name := metricName(key)
+
+	s.mu.Lock()
+	counter, ok := s.counters[name]
+	if !ok {
+		counter, _ = s.meter.Float64Counter(name)
+		s.counters[name] = counter
+	}
+	s.mu.Unlock()
+
+	counter.Add(context.Background(), float64(val))
+}
+
+// AddSample implements gometrics.MetricSink.
+func (s *goMetricsSink) AddSample(key []string, val float32) {
+	s.AddSampleWithLabels(key, val, nil)
+}
+
+// AddSampleWithLabels implements gometrics.MetricSink.
+func (s *goMetricsSink) AddSampleWithLabels(key []string, val float32, _ []gometrics.Label) {
+	name := metricName(key)
+
+	s.mu.Lock()
+	histogram, ok := s.histograms[name]
+	if !ok {
+		histogram, _ = s.meter.Float64Histogram(name)
+		s.histograms[name] = histogram
+	}
+	s.mu.Unlock()
+
+	histogram.Record(context.Background(), float64(val))
+}
@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		name string
+		key  []string
+		want string
+	}{
+		{name: "single segment", key: []string{"diskSize"}, want: "diskSize"},
+		{name: "multiple segments", key: []string{"leveldb", "blockchain", "ioRead"}, want: "leveldb.blockchain.ioRead"},
+		{name: "empty key", key: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metricName(tt.key); got != tt.want {
+				t.Errorf("metricName(%v) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoMetricsSinkSetGauge(t *testing.T) {
+	sink := newGoMetricsSink(noop.NewMeterProvider())
+
+	sink.SetGauge([]string{"a", "b"}, 1.5)
+
+	if got := sink.gauges["a.b"]; got != 1.5 {
+		t.Errorf("gauge a.b = %v, want 1.5", got)
+	}
+
+	// Re-setting the same key updates the value without registering a
+	// second observable gauge.
+	sink.SetGauge([]string{"a", "b"}, 2.5)
+
+	if got := sink.gauges["a.b"]; got != 2.5 {
+		t.Errorf("gauge a.b = %v, want 2.5", got)
+	}
+
+	if len(sink.gauges) != 1 {
+		t.Errorf("got %d distinct gauges, want 1", len(sink.gauges))
+	}
+}
+
+func TestGoMetricsSinkIncrCounter(t *testing.T) {
+	sink := newGoMetricsSink(noop.NewMeterProvider())
+
+	sink.IncrCounter([]string{"requests"}, 1)
+	sink.IncrCounter([]string{"requests"}, 1)
+
+	if _, ok := sink.counters["requests"]; !ok {
+		t.Fatalf("expected a counter to be registered for %q", "requests")
+	}
+}
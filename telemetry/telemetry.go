@@ -0,0 +1,151 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// otlpProtocolEnv is the standard env var OTLP SDKs use to choose between
+// the gRPC and HTTP exporter transports.
+const otlpProtocolEnv = "OTEL_EXPORTER_OTLP_PROTOCOL"
+
+// protocol identifies which OTLP transport to dial, mirroring the values
+// the spec defines for OTEL_EXPORTER_OTLP_PROTOCOL.
+type protocol string
+
+const (
+	protocolGRPC      protocol = "grpc"
+	protocolHTTPProto protocol = "http/protobuf"
+	protocolHTTPJSON  protocol = "http/json"
+)
+
+func protocolFromEnv() protocol {
+	switch strings.ToLower(os.Getenv(otlpProtocolEnv)) {
+	case string(protocolHTTPProto):
+		return protocolHTTPProto
+	case string(protocolHTTPJSON):
+		return protocolHTTPJSON
+	default:
+		return protocolGRPC
+	}
+}
+
+// Telemetry owns the OTLP metric and trace providers for the process.
+type Telemetry struct {
+	meterProvider  *sdkmetric.MeterProvider
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// Start wires an OTLP exporter (gRPC or HTTP, selected via
+// OTEL_EXPORTER_OTLP_PROTOCOL) for metrics and traces, configured through the
+// standard OTEL_EXPORTER_OTLP_ENDPOINT / OTEL_SERVICE_NAME /
+// OTEL_RESOURCE_ATTRIBUTES env vars. It returns a nil Telemetry (and nil
+// error) when no endpoint is configured, so OTLP export stays opt-in.
+func Start(ctx context.Context) (*Telemetry, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" {
+		return nil, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithAttributes(semconv.ServiceNameKey.String(serviceName())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	proto := protocolFromEnv()
+
+	metricExporter, err := newMetricExporter(ctx, proto)
+	if err != nil {
+		return nil, err
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+	)
+	otel.SetMeterProvider(meterProvider)
+
+	traceExporter, err := newTraceExporter(ctx, proto)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(traceExporter),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return &Telemetry{meterProvider: meterProvider, tracerProvider: tracerProvider}, nil
+}
+
+// Shutdown flushes and closes the metric and trace providers. It is a no-op
+// on a nil Telemetry, so callers don't need to guard every shutdown path on
+// whether OTLP export was actually enabled.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	if err := t.tracerProvider.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	return t.meterProvider.Shutdown(ctx)
+}
+
+// Sink returns an armon/go-metrics sink that forwards every emitted metric
+// into the OTel meter provider, so existing instrumentation (e.g. the
+// database metering goroutine) reaches the OTLP pipeline unchanged.
+func (t *Telemetry) Sink() *goMetricsSink {
+	return newGoMetricsSink(t.meterProvider)
+}
+
+func serviceName() string {
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		return name
+	}
+
+	return "polygon-edge"
+}
+
+// newMetricExporter builds the gRPC or HTTP OTLP metric exporter named by
+// proto. Both transports pick up their endpoint/headers/TLS settings from
+// the standard OTEL_EXPORTER_OTLP_* env vars on their own.
+func newMetricExporter(ctx context.Context, proto protocol) (sdkmetric.Exporter, error) {
+	switch proto {
+	case protocolHTTPProto, protocolHTTPJSON:
+		return otlpmetrichttp.New(ctx)
+	case protocolGRPC:
+		return otlpmetricgrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported %s %q", otlpProtocolEnv, proto)
+	}
+}
+
+// newTraceExporter builds the gRPC or HTTP OTLP trace exporter named by
+// proto.
+func newTraceExporter(ctx context.Context, proto protocol) (sdktrace.SpanExporter, error) {
+	switch proto {
+	case protocolHTTPProto, protocolHTTPJSON:
+		return otlptracehttp.New(ctx)
+	case protocolGRPC:
+		return otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported %s %q", otlpProtocolEnv, proto)
+	}
+}
@@ -0,0 +1,28 @@
+package telemetry
+
+import "testing"
+
+func TestProtocolFromEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  protocol
+	}{
+		{name: "unset defaults to grpc", value: "", want: protocolGRPC},
+		{name: "explicit grpc", value: "grpc", want: protocolGRPC},
+		{name: "http protobuf", value: "http/protobuf", want: protocolHTTPProto},
+		{name: "http json", value: "http/json", want: protocolHTTPJSON},
+		{name: "case insensitive", value: "HTTP/Protobuf", want: protocolHTTPProto},
+		{name: "unknown value defaults to grpc", value: "carrier-pigeon", want: protocolGRPC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(otlpProtocolEnv, tt.value)
+
+			if got := protocolFromEnv(); got != tt.want {
+				t.Errorf("protocolFromEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}